@@ -0,0 +1,81 @@
+package commentgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// cacheKey returns the SHA-256 hex digest of the signature, prompt, and
+// model, so a re-run only regenerates comments when any of those change.
+func cacheKey(signature, prompt, model string) string {
+	sum := sha256.Sum256([]byte(signature + "\x00" + prompt + "\x00" + model))
+	return hex.EncodeToString(sum[:])
+}
+
+// cache is a persistent on-disk store of previously generated comments,
+// keyed by cacheKey. It is safe for concurrent use.
+type cache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]string
+	dirty   bool
+}
+
+func loadCache(path string) (*cache, error) {
+	c := &cache{path: path, entries: map[string]string{}}
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading cache file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("parsing cache file %s: %w", path, err)
+	}
+	return c, nil
+}
+
+func (c *cache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.entries[key]
+	return v, ok
+}
+
+func (c *cache) set(key, value string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = value
+	c.dirty = true
+}
+
+// save writes the cache to disk if it has changed since it was loaded. It is
+// a no-op when no cache path was configured.
+func (c *cache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.path == "" || !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0o644); err != nil {
+		return fmt.Errorf("writing cache file %s: %w", c.path, err)
+	}
+	c.dirty = false
+	return nil
+}
@@ -0,0 +1,313 @@
+// Package commentgen generates doc comments for function signatures using an
+// LLM, batching and parallelizing requests and caching results on disk so
+// re-runs only pay for what changed.
+package commentgen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Forgence/Flandra/internal/llm"
+)
+
+// Signature is a function signature to generate a doc comment for.
+type Signature struct {
+	// Name identifies the signature within a batch; callers typically use
+	// the function name, but it only needs to be unique within one Generate
+	// call.
+	Name string
+	Text string
+}
+
+// Config controls how a Generator talks to the LLM and manages its cache.
+type Config struct {
+	// Provider selects the LLM backend: "openai" (default), "azure",
+	// "anthropic", or "local". See internal/llm.
+	Provider string
+	APIKey   string
+	// BaseURL is the API endpoint for "azure" and "local", where it is
+	// required; other providers ignore it.
+	BaseURL string
+	// APIVersion overrides the Azure API version; ignored by other
+	// providers.
+	APIVersion  string
+	Model       string
+	Temperature float32
+	MaxTokens   int
+	// Concurrency is the number of batches processed in parallel.
+	Concurrency int
+	// BatchSize is the number of signatures bundled into a single chat
+	// completion request.
+	BatchSize int
+	// CacheFile is the path to a JSON file persisting generated comments
+	// across runs. Empty disables caching.
+	CacheFile string
+	// RequestTimeout bounds a single batch request, including retries.
+	RequestTimeout time.Duration
+}
+
+// DefaultConfig returns the Config Flandra falls back to when the caller
+// doesn't override individual fields.
+func DefaultConfig() Config {
+	return Config{
+		Provider:       "openai",
+		Model:          "gpt-4",
+		Temperature:    0.5,
+		MaxTokens:      256,
+		Concurrency:    4,
+		BatchSize:      10,
+		CacheFile:      ".flandra_cache.json",
+		RequestTimeout: 30 * time.Second,
+	}
+}
+
+// Stats summarizes a Generator's work across its lifetime, suitable for
+// printing at the end of a run.
+type Stats struct {
+	CacheHits    int
+	CacheMisses  int
+	PromptTokens int
+	TotalTokens  int
+}
+
+// Generator produces doc comments for batches of function signatures,
+// fanning requests out over a bounded worker pool and skipping any
+// signature already present in its on-disk cache.
+type Generator struct {
+	cfg      Config
+	provider llm.Provider
+	cache    *cache
+
+	mu    sync.Mutex
+	stats Stats
+}
+
+// New constructs a Generator from cfg, loading its on-disk cache if
+// configured.
+func New(cfg Config) (*Generator, error) {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1
+	}
+
+	c, err := loadCache(cfg.CacheFile)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := llm.New(llm.Config{
+		Provider:   cfg.Provider,
+		APIKey:     cfg.APIKey,
+		BaseURL:    cfg.BaseURL,
+		APIVersion: cfg.APIVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Generator{
+		cfg:      cfg,
+		provider: provider,
+		cache:    c,
+	}, nil
+}
+
+// Generate returns a doc comment for each signature, keyed by Signature.Name.
+// Cached signatures are resolved without contacting the LLM; the rest are
+// grouped into batches of cfg.BatchSize and processed by cfg.Concurrency
+// workers concurrently. A signature whose batch fails after retries is
+// omitted from the result map, and that batch's error is joined into the
+// returned error; results from the other batches are still returned.
+func (g *Generator) Generate(ctx context.Context, sigs []Signature) (map[string]string, error) {
+	results := make(map[string]string, len(sigs))
+	var pending []Signature
+
+	for _, sig := range sigs {
+		key := cacheKey(sig.Text, promptFor([]Signature{sig}), g.cfg.Model)
+		if doc, ok := g.cache.get(key); ok {
+			results[sig.Name] = doc
+			g.recordCacheHit()
+			continue
+		}
+		pending = append(pending, sig)
+	}
+
+	batches := batch(pending, g.cfg.BatchSize)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, g.cfg.Concurrency)
+		errs []error
+	)
+
+	for _, b := range batches {
+		b := b
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			docs, err := g.generateBatch(ctx, b)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			for name, doc := range docs {
+				results[name] = doc
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := g.cache.save(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// Stats returns a snapshot of the Generator's cumulative usage.
+func (g *Generator) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stats
+}
+
+func (g *Generator) recordCacheHit() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stats.CacheHits++
+}
+
+// generateBatch requests doc comments for a single batch, retrying on 429
+// and 5xx responses with exponential backoff bounded by cfg.RequestTimeout,
+// and populates the cache with whatever comes back.
+func (g *Generator) generateBatch(ctx context.Context, sigs []Signature) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, g.cfg.RequestTimeout)
+	defer cancel()
+
+	prompt := llm.Prompt{
+		System:      "You are a helpful assistant that writes concise Go-style doc comments for function signatures. Respond only with JSON matching the requested schema, with no comment markers in the text itself.",
+		User:        promptFor(sigs),
+		Model:       g.cfg.Model,
+		Temperature: g.cfg.Temperature,
+		MaxTokens:   g.cfg.MaxTokens * len(sigs),
+		JSONMode:    true,
+	}
+
+	content, usage, err := g.withRetry(ctx, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("generating comments for batch starting with %q: %w", sigs[0].Name, err)
+	}
+
+	g.mu.Lock()
+	g.stats.PromptTokens += usage.PromptTokens
+	g.stats.TotalTokens += usage.TotalTokens
+	g.mu.Unlock()
+
+	docs, err := parseBatchResponse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing batch response: %w", err)
+	}
+
+	for _, sig := range sigs {
+		if doc, ok := docs[sig.Name]; ok {
+			key := cacheKey(sig.Text, promptFor([]Signature{sig}), g.cfg.Model)
+			g.cache.set(key, doc)
+		}
+	}
+
+	g.mu.Lock()
+	g.stats.CacheMisses += len(sigs)
+	g.mu.Unlock()
+
+	return docs, nil
+}
+
+// withRetry runs prompt against g.provider with exponential backoff on
+// errors the provider marks retryable, giving up once ctx is done.
+func (g *Generator) withRetry(ctx context.Context, prompt llm.Prompt) (string, llm.Usage, error) {
+	const maxAttempts = 5
+	backoff := 500 * time.Millisecond
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		content, usage, err := g.provider.Complete(ctx, prompt)
+		if err == nil {
+			return content, usage, nil
+		}
+		lastErr = err
+
+		var retryable *llm.RetryableError
+		if !errors.As(err, &retryable) {
+			return "", llm.Usage{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", llm.Usage{}, ctx.Err()
+		case <-time.After(jitter(backoff)):
+		}
+		backoff *= 2
+	}
+	return "", llm.Usage{}, lastErr
+}
+
+// jitter returns d plus up to 20% random variance, to avoid retry storms
+// from multiple workers backing off in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
+
+func batch(sigs []Signature, size int) [][]Signature {
+	var batches [][]Signature
+	for i := 0; i < len(sigs); i += size {
+		end := i + size
+		if end > len(sigs) {
+			end = len(sigs)
+		}
+		batches = append(batches, sigs[i:end])
+	}
+	return batches
+}
+
+func promptFor(sigs []Signature) string {
+	prompt := "Generate a one-sentence doc comment for each of the following Go function signatures. " +
+		`Respond with a JSON object of the form {"comments": [{"name": "...", "comment": "..."}]}.` + "\n\n"
+	for _, sig := range sigs {
+		prompt += fmt.Sprintf("- %s: %s\n", sig.Name, sig.Text)
+	}
+	return prompt
+}
+
+type batchResponse struct {
+	Comments []struct {
+		Name    string `json:"name"`
+		Comment string `json:"comment"`
+	} `json:"comments"`
+}
+
+func parseBatchResponse(content string) (map[string]string, error) {
+	var parsed batchResponse
+	if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]string, len(parsed.Comments))
+	for _, c := range parsed.Comments {
+		docs[c.Name] = c.Comment
+	}
+	return docs, nil
+}
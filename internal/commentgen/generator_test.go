@@ -0,0 +1,126 @@
+package commentgen
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Forgence/Flandra/internal/llm"
+)
+
+// fakeProvider is a scripted llm.Provider for exercising Generator without a
+// real API: each call returns the next entry of responses, or returns err
+// if the script has one queued for that call index.
+type fakeProvider struct {
+	mu    sync.Mutex
+	calls int
+
+	// responses[i] is returned on the i-th call, repeating the last entry
+	// once exhausted.
+	responses []string
+	errs      []error
+}
+
+func (f *fakeProvider) Complete(ctx context.Context, prompt llm.Prompt) (string, llm.Usage, error) {
+	f.mu.Lock()
+	i := f.calls
+	f.calls++
+	f.mu.Unlock()
+
+	if i < len(f.errs) && f.errs[i] != nil {
+		return "", llm.Usage{}, f.errs[i]
+	}
+	resp := f.responses[len(f.responses)-1]
+	if i < len(f.responses) {
+		resp = f.responses[i]
+	}
+	return resp, llm.Usage{PromptTokens: 1, TotalTokens: 2}, nil
+}
+
+func newTestGenerator(cfg Config, p llm.Provider) *Generator {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1
+	}
+	c, _ := loadCache(cfg.CacheFile)
+	return &Generator{cfg: cfg, provider: p, cache: c}
+}
+
+func TestGeneratorGenerateBatchesAndCaches(t *testing.T) {
+	p := &fakeProvider{responses: []string{
+		`{"comments": [{"name": "Foo", "comment": "Foo does a thing."}, {"name": "Bar", "comment": "Bar does another."}]}`,
+	}}
+	g := newTestGenerator(Config{BatchSize: 10, Concurrency: 2, RequestTimeout: time.Second, Model: "test-model"}, p)
+
+	docs, err := g.Generate(context.Background(), []Signature{
+		{Name: "Foo", Text: "func Foo()"},
+		{Name: "Bar", Text: "func Bar()"},
+	})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if docs["Foo"] != "Foo does a thing." || docs["Bar"] != "Bar does another." {
+		t.Fatalf("got %+v", docs)
+	}
+	if p.calls != 1 {
+		t.Fatalf("expected 1 batch call, got %d", p.calls)
+	}
+
+	// A second Generate call for the same signatures should hit the cache
+	// and make no further calls to the provider.
+	docs2, err := g.Generate(context.Background(), []Signature{
+		{Name: "Foo", Text: "func Foo()"},
+	})
+	if err != nil {
+		t.Fatalf("Generate (cached): %v", err)
+	}
+	if docs2["Foo"] != "Foo does a thing." {
+		t.Fatalf("got %+v", docs2)
+	}
+	if p.calls != 1 {
+		t.Fatalf("expected cache hit to avoid a second call, got %d calls", p.calls)
+	}
+	if g.Stats().CacheHits != 1 {
+		t.Fatalf("expected 1 cache hit, got %+v", g.Stats())
+	}
+}
+
+func TestGeneratorWithRetryRetriesRetryableErrors(t *testing.T) {
+	p := &fakeProvider{
+		errs:      []error{&llm.RetryableError{Err: context.DeadlineExceeded}, nil},
+		responses: []string{"", `{"comments": [{"name": "Foo", "comment": "ok"}]}`},
+	}
+	g := newTestGenerator(Config{BatchSize: 10, Concurrency: 1, RequestTimeout: 5 * time.Second, Model: "test-model"}, p)
+
+	content, _, err := g.withRetry(context.Background(), llm.Prompt{})
+	if err != nil {
+		t.Fatalf("withRetry: %v", err)
+	}
+	if content != `{"comments": [{"name": "Foo", "comment": "ok"}]}` {
+		t.Fatalf("got %q", content)
+	}
+	if p.calls != 2 {
+		t.Fatalf("expected a retry after the first failure, got %d calls", p.calls)
+	}
+}
+
+func TestGeneratorWithRetryGivesUpOnNonRetryableError(t *testing.T) {
+	boom := errContext("boom")
+	p := &fakeProvider{errs: []error{boom}, responses: []string{""}}
+	g := newTestGenerator(Config{BatchSize: 10, Concurrency: 1, RequestTimeout: time.Second, Model: "test-model"}, p)
+
+	_, _, err := g.withRetry(context.Background(), llm.Prompt{})
+	if err != boom {
+		t.Fatalf("got %v, want %v", err, boom)
+	}
+	if p.calls != 1 {
+		t.Fatalf("expected no retry for a non-retryable error, got %d calls", p.calls)
+	}
+}
+
+type errContext string
+
+func (e errContext) Error() string { return string(e) }
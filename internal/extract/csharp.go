@@ -0,0 +1,71 @@
+package extract
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/csharp"
+)
+
+func init() {
+	Register(".cs", &CSharpExtractor{})
+}
+
+// CSharpExtractor extracts imports, globals, and funcs from C# source using
+// tree-sitter.
+type CSharpExtractor struct{}
+
+func (CSharpExtractor) Language() string { return "C#" }
+
+func (CSharpExtractor) ExtractImports(content string) ([]string, error) {
+	root, src, err := tsParse(csharp.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	err = tsEachMatch(csharp.GetLanguage(), `(using_directive) @using`, root, src, func(c map[string]*sitter.Node) {
+		imports = append(imports, nodeText(c["using"], src))
+	})
+	return imports, err
+}
+
+func (CSharpExtractor) ExtractGlobals(content string) ([]string, error) {
+	root, src, err := tsParse(csharp.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var globals []string
+	err = tsEachMatch(csharp.GetLanguage(), `(field_declaration) @field`, root, src, func(c map[string]*sitter.Node) {
+		globals = append(globals, nodeText(c["field"], src))
+	})
+	return globals, err
+}
+
+func (CSharpExtractor) ExtractFuncs(content string) ([]FuncDecl, error) {
+	root, src, err := tsParse(csharp.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []FuncDecl
+	query := `(method_declaration name: (identifier) @name parameters: (parameter_list) @params) @func`
+	err = tsEachMatch(csharp.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		funcs = append(funcs, FuncDecl{
+			Name:   nodeText(c["name"], src),
+			Recv:   enclosingReceiver(c["func"], csharpClassNodeTypes, "name", src),
+			Params: nodeText(c["params"], src),
+			Doc:    leadingComment(c["func"], src),
+		})
+	})
+	return funcs, err
+}
+
+// csharpClassNodeTypes are the tree-sitter node types for a C# class/struct
+// body, the closest analogue of a Go method receiver: two classes defining
+// the same method name (commonly an override such as ToString) are otherwise
+// indistinguishable. Constructors are not method_declaration nodes and are
+// not extracted.
+var csharpClassNodeTypes = map[string]bool{
+	"class_declaration":  true,
+	"struct_declaration": true,
+}
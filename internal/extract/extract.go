@@ -0,0 +1,179 @@
+// Package extract defines the pluggable extraction subsystem used by Flandra
+// to pull imports, globals, and function declarations out of source files in
+// a variety of languages.
+package extract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FuncDecl describes a single function or method declaration found in a
+// source file, independent of source language.
+type FuncDecl struct {
+	Name string
+	// Recv is the bare receiver type name for a method (e.g. "Foo" for both
+	// "f Foo" and "f *Foo" receivers), or empty for a plain function.
+	// Distinguishes e.g. two unrelated Close() methods from each other;
+	// callers that key on Name alone will conflate them.
+	Recv    string
+	Params  string
+	Results string
+	// Doc is the function's existing doc comment, read verbatim from the
+	// source. Empty if the function had none.
+	Doc string
+	// GeneratedDoc is LLM-produced prose for this function, set by the
+	// comment-generation pass in main. It supplements Doc (with
+	// -mergeComments) rather than replacing it, and has no position in the
+	// original source.
+	GeneratedDoc string
+}
+
+// FileCode is the structured result of extracting a single file. It replaces
+// the old pre-rendered string output so callers can choose how to render it.
+type FileCode struct {
+	Filename string
+	Language string
+	Imports  []string
+	Globals  []string
+	Funcs    []FuncDecl
+}
+
+// Extractor pulls imports, globals, and function declarations out of a
+// single file's content. Implementations are registered by file extension
+// via Register and looked up with For.
+type Extractor interface {
+	// Language returns the human-readable name of the language this
+	// extractor handles, e.g. "Go" or "Python".
+	Language() string
+	ExtractImports(content string) ([]string, error)
+	ExtractGlobals(content string) ([]string, error)
+	ExtractFuncs(content string) ([]FuncDecl, error)
+}
+
+// Renderer is implemented by extractors that can render a FileCode back
+// into properly formatted source text for their language, rather than
+// leaving callers to fall back to the generic plain-text rendering. The
+// want* flags mirror the ones passed to File and tell the renderer which
+// parts of fc were actually requested, since an empty slice is ambiguous
+// with "not requested".
+type Renderer interface {
+	Render(content string, fc FileCode, wantImports, wantGlobals, wantFuncs bool) (string, error)
+}
+
+var registry = map[string]Extractor{}
+
+// Register associates an Extractor with a file extension (including the
+// leading dot, e.g. ".go"). It is typically called from package init funcs,
+// but is exported so callers can register custom extractors by extension at
+// startup.
+func Register(ext string, e Extractor) {
+	registry[ext] = e
+}
+
+// For returns the Extractor registered for ext, if any.
+func For(ext string) (Extractor, bool) {
+	e, ok := registry[ext]
+	return e, ok
+}
+
+// File runs the appropriate extractor for file's extension against content,
+// honoring the extractImports/extractGlobals/extractFuncs toggles, and
+// returns a structured FileCode. It returns an error if no extractor is
+// registered for the file's extension.
+func File(file, content string, ext string, wantImports, wantGlobals, wantFuncs bool) (FileCode, error) {
+	e, ok := For(ext)
+	if !ok {
+		return FileCode{}, fmt.Errorf("no extractor registered for extension %q", ext)
+	}
+
+	fc := FileCode{Filename: file, Language: e.Language()}
+
+	if wantImports {
+		imports, err := e.ExtractImports(content)
+		if err != nil {
+			return FileCode{}, fmt.Errorf("extracting imports: %w", err)
+		}
+		fc.Imports = imports
+	}
+
+	if wantGlobals {
+		globals, err := e.ExtractGlobals(content)
+		if err != nil {
+			return FileCode{}, fmt.Errorf("extracting globals: %w", err)
+		}
+		fc.Globals = globals
+	}
+
+	if wantFuncs {
+		funcs, err := e.ExtractFuncs(content)
+		if err != nil {
+			return FileCode{}, fmt.Errorf("extracting funcs: %w", err)
+		}
+		fc.Funcs = funcs
+	}
+
+	return fc, nil
+}
+
+// Render renders fc back into source text using the Renderer registered for
+// ext, if any. The second return value is false if the registered extractor
+// does not implement Renderer, in which case callers should fall back to
+// their own generic rendering.
+func Render(ext, content string, fc FileCode, wantImports, wantGlobals, wantFuncs bool) (string, bool, error) {
+	e, ok := For(ext)
+	if !ok {
+		return "", false, fmt.Errorf("no extractor registered for extension %q", ext)
+	}
+
+	r, ok := e.(Renderer)
+	if !ok {
+		return "", false, nil
+	}
+
+	out, err := r.Render(content, fc, wantImports, wantGlobals, wantFuncs)
+	if err != nil {
+		return "", true, err
+	}
+	return out, true, nil
+}
+
+// PlainText is the generic fallback rendering for languages whose extractor
+// does not implement Renderer: one import/global per line, followed by a
+// stub for each function with its doc comment.
+func PlainText(fc FileCode) string {
+	var buf strings.Builder
+
+	for _, imp := range fc.Imports {
+		buf.WriteString("import ")
+		buf.WriteString(imp)
+		buf.WriteString("\n")
+	}
+
+	for _, global := range fc.Globals {
+		buf.WriteString(global)
+		buf.WriteString("\n")
+	}
+
+	for _, fn := range fc.Funcs {
+		buf.WriteString("func ")
+		if fn.Recv != "" {
+			buf.WriteString("(" + fn.Recv + ") ")
+		}
+		buf.WriteString(fn.Name)
+		buf.WriteString(fn.Params)
+		if fn.Results != "" {
+			buf.WriteString(" " + fn.Results)
+		}
+		buf.WriteString(" {\n")
+		if fn.Doc != "" {
+			buf.WriteString("// " + fn.Doc + "\n")
+		}
+		if fn.GeneratedDoc != "" {
+			buf.WriteString("// " + fn.GeneratedDoc + "\n")
+		}
+		buf.WriteString("}\n")
+	}
+
+	return buf.String()
+}
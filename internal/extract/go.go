@@ -0,0 +1,266 @@
+package extract
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"strings"
+)
+
+func init() {
+	Register(".go", &GoExtractor{})
+}
+
+// GoExtractor extracts imports, globals, and funcs from Go source using
+// go/parser and go/ast.
+type GoExtractor struct{}
+
+func (GoExtractor) Language() string { return "Go" }
+
+func (GoExtractor) ExtractImports(content string) ([]string, error) {
+	f, fset, err := parseGo(content)
+	_ = fset
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	for _, imp := range f.Imports {
+		imports = append(imports, imp.Path.Value)
+	}
+	return imports, nil
+}
+
+func (GoExtractor) ExtractGlobals(content string) ([]string, error) {
+	f, fset, err := parseGo(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var globals []string
+	for _, decl := range f.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range d.Specs {
+			s, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			var names []string
+			for _, name := range s.Names {
+				names = append(names, name.Name)
+			}
+			var typeBuf bytes.Buffer
+			printer.Fprint(&typeBuf, fset, s.Type)
+			globals = append(globals, fmt.Sprintf("var %s %s", strings.Join(names, " "), typeBuf.String()))
+		}
+	}
+	return globals, nil
+}
+
+func (GoExtractor) ExtractFuncs(content string) ([]FuncDecl, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Go code: %v", err)
+	}
+
+	cmap := ast.NewCommentMap(fset, f, f.Comments)
+
+	var funcs []FuncDecl
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+
+		doc := fn.Doc.Text()
+		if doc == "" {
+			for _, group := range cmap[fn] {
+				doc += group.Text()
+			}
+		}
+
+		funcs = append(funcs, FuncDecl{
+			Name:    fn.Name.Name,
+			Recv:    ReceiverType(fset, fn.Recv),
+			Params:  formatFieldList(fn.Type.Params),
+			Results: formatFieldList(fn.Type.Results),
+			Doc:     strings.TrimSpace(doc),
+		})
+	}
+	return funcs, nil
+}
+
+// ReceiverType returns the bare receiver type name for recv (e.g. "Foo" for
+// both "f Foo" and "f *Foo"), or "" if recv is nil or empty. Exported so
+// other packages that walk the same *ast.FuncDecl nodes (e.g.
+// internal/inject) can key generated docs by receiver+name the same way
+// ExtractFuncs does, rather than risk conflating two distinct methods that
+// happen to share a name.
+func ReceiverType(fset *token.FileSet, recv *ast.FieldList) string {
+	if recv == nil || len(recv.List) == 0 {
+		return ""
+	}
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, recv.List[0].Type)
+	return strings.TrimPrefix(buf.String(), "*")
+}
+
+// Render rebuilds a gofmt-compliant Go source snippet from content: import
+// and var decls are kept as-is, and each function's body is replaced with an
+// empty block. Existing doc comments round-trip verbatim through
+// ast.CommentMap and go/format.Node. Each func's GeneratedDoc, if set, is
+// spliced in as an additional `//` comment immediately above it (below any
+// preserved original doc, per -mergeComments), since generated text has no
+// real source position to carry through the comment map.
+func (GoExtractor) Render(content string, fc FileCode, wantImports, wantGlobals, wantFuncs bool) (string, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return "", fmt.Errorf("error parsing Go code: %v", err)
+	}
+	cmap := ast.NewCommentMap(fset, f, f.Comments)
+
+	generatedByKey := make(map[funcKey]string, len(fc.Funcs))
+	for _, fn := range fc.Funcs {
+		generatedByKey[funcKey{Recv: fn.Recv, Name: fn.Name}] = fn.GeneratedDoc
+	}
+
+	var kept []ast.Decl
+	extraDoc := map[funcKey]string{}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT && wantImports {
+				kept = append(kept, d)
+			} else if d.Tok == token.VAR && wantGlobals {
+				kept = append(kept, d)
+			}
+		case *ast.FuncDecl:
+			if !wantFuncs {
+				continue
+			}
+			d.Body = &ast.BlockStmt{}
+			key := funcKey{Recv: ReceiverType(fset, d.Recv), Name: d.Name.Name}
+			if doc := generatedByKey[key]; doc != "" {
+				extraDoc[key] = doc
+			}
+			kept = append(kept, d)
+		}
+	}
+
+	newFile := &ast.File{Name: f.Name, Decls: kept}
+	newFile.Comments = cmap.Filter(newFile).Comments()
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, newFile); err != nil {
+		return "", fmt.Errorf("formatting extracted Go source: %v", err)
+	}
+
+	return spliceGeneratedDocs(buf.String(), extraDoc), nil
+}
+
+// funcKey identifies a function or method by receiver type and name, since
+// name alone is ambiguous whenever two distinct types implement the same
+// method name (Close, String, Error, ...).
+type funcKey struct {
+	Recv, Name string
+}
+
+// spliceGeneratedDocs prepends a `// doc` comment above each "func Name("
+// line found in src for which extraDoc has an entry. Used for doc text that
+// was generated rather than read from the original source, and therefore
+// has no position to attach through ast.CommentMap.
+func spliceGeneratedDocs(src string, extraDoc map[funcKey]string) string {
+	if len(extraDoc) == 0 {
+		return src
+	}
+
+	lines := strings.Split(src, "\n")
+	var out []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "func ") {
+			if key, ok := funcKeyFromSig(trimmed); ok {
+				if doc, ok := extraDoc[key]; ok {
+					out = append(out, "// "+doc)
+				}
+			}
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// funcKeyFromSig extracts the receiver type and function name from a
+// "func Name(...", "func Name[T any](...", or "func (recv T) Name(..."
+// signature line.
+func funcKeyFromSig(sig string) (funcKey, bool) {
+	sig = strings.TrimPrefix(sig, "func ")
+
+	var recv string
+	if strings.HasPrefix(sig, "(") {
+		idx := strings.Index(sig, ")")
+		if idx == -1 {
+			return funcKey{}, false
+		}
+		recv = receiverTypeFromText(sig[1:idx])
+		sig = strings.TrimSpace(sig[idx+1:])
+	}
+
+	// The name ends at the parameter list or, for a generic function, at
+	// its type parameter list, whichever comes first.
+	idx := strings.IndexAny(sig, "([")
+	if idx == -1 {
+		return funcKey{}, false
+	}
+	return funcKey{Recv: recv, Name: sig[:idx]}, true
+}
+
+// receiverTypeFromText returns the bare type name out of a printed receiver
+// field like "f *Foo" or "Foo", matching what ReceiverType computes from the
+// real *ast.FieldList.
+func receiverTypeFromText(recv string) string {
+	fields := strings.Fields(recv)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[len(fields)-1], "*")
+}
+
+func parseGo(content string) (*ast.File, *token.FileSet, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing Go code: %v", err)
+	}
+	return f, fset, nil
+}
+
+func formatFieldList(fields *ast.FieldList) string {
+	if fields == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, field := range fields.List {
+		var typeBuf bytes.Buffer
+		printer.Fprint(&typeBuf, token.NewFileSet(), field.Type)
+
+		if len(field.Names) == 0 {
+			parts = append(parts, typeBuf.String())
+			continue
+		}
+		for _, name := range field.Names {
+			parts = append(parts, name.Name+" "+typeBuf.String())
+		}
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,74 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoExtractorRenderKeysMethodsByReceiver(t *testing.T) {
+	content := `package sample
+
+type Foo struct{}
+type Bar struct{}
+
+func (f *Foo) Close() error { return nil }
+
+func (b *Bar) Close() error { return nil }
+`
+	e := GoExtractor{}
+	funcs, err := e.ExtractFuncs(content)
+	if err != nil {
+		t.Fatalf("ExtractFuncs: %v", err)
+	}
+	if len(funcs) != 2 {
+		t.Fatalf("got %d funcs, want 2", len(funcs))
+	}
+
+	for i := range funcs {
+		switch funcs[i].Recv {
+		case "Foo":
+			funcs[i].GeneratedDoc = "Close closes the Foo."
+		case "Bar":
+			funcs[i].GeneratedDoc = "Close closes the Bar."
+		default:
+			t.Fatalf("unexpected receiver %q", funcs[i].Recv)
+		}
+	}
+
+	out, err := e.Render(content, FileCode{Funcs: funcs}, false, false, true)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	if !strings.Contains(out, "// Close closes the Foo.\nfunc (f *Foo) Close()") {
+		t.Errorf("Foo.Close did not get its own doc comment:\n%s", out)
+	}
+	if !strings.Contains(out, "// Close closes the Bar.\nfunc (b *Bar) Close()") {
+		t.Errorf("Bar.Close did not get its own doc comment:\n%s", out)
+	}
+}
+
+func TestGoExtractorRenderGenericFunc(t *testing.T) {
+	content := `package sample
+
+func Foo[T any](x T) T { return x }
+`
+	e := GoExtractor{}
+	funcs, err := e.ExtractFuncs(content)
+	if err != nil {
+		t.Fatalf("ExtractFuncs: %v", err)
+	}
+	if len(funcs) != 1 || funcs[0].Name != "Foo" {
+		t.Fatalf("got %+v, want a single func named Foo", funcs)
+	}
+	funcs[0].GeneratedDoc = "Foo returns x unchanged."
+
+	out, err := e.Render(content, FileCode{Funcs: funcs}, false, false, true)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	if !strings.Contains(out, "// Foo returns x unchanged.\nfunc Foo[T any]") {
+		t.Errorf("generated doc was not attached to the generic func:\n%s", out)
+	}
+}
@@ -0,0 +1,90 @@
+package extract
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/python"
+)
+
+func init() {
+	Register(".py", &PythonExtractor{})
+}
+
+// PythonExtractor extracts imports, globals, and funcs from Python source
+// using tree-sitter.
+type PythonExtractor struct{}
+
+func (PythonExtractor) Language() string { return "Python" }
+
+func (PythonExtractor) ExtractImports(content string) ([]string, error) {
+	root, src, err := tsParse(python.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	query := `[(import_statement) (import_from_statement)] @import`
+	err = tsEachMatch(python.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		imports = append(imports, nodeText(c["import"], src))
+	})
+	return imports, err
+}
+
+func (PythonExtractor) ExtractGlobals(content string) ([]string, error) {
+	root, src, err := tsParse(python.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var globals []string
+	query := `(module (expression_statement (assignment) @assign))`
+	err = tsEachMatch(python.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		globals = append(globals, nodeText(c["assign"], src))
+	})
+	return globals, err
+}
+
+func (PythonExtractor) ExtractFuncs(content string) ([]FuncDecl, error) {
+	root, src, err := tsParse(python.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []FuncDecl
+	query := `(function_definition name: (identifier) @name parameters: (parameters) @params) @func`
+	err = tsEachMatch(python.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		funcs = append(funcs, FuncDecl{
+			Name:   nodeText(c["name"], src),
+			Recv:   enclosingReceiver(c["func"], classNodeTypes, "name", src),
+			Params: nodeText(c["params"], src),
+			Doc:    docstring(c["func"], src),
+		})
+	})
+	return funcs, err
+}
+
+// classNodeTypes are the tree-sitter node types for a Python class body, the
+// closest analogue of a Go method receiver: two classes defining the same
+// method name (commonly "__init__") are otherwise indistinguishable.
+var classNodeTypes = map[string]bool{
+	"class_definition": true,
+}
+
+// docstring returns the text of fn's first statement if it is a bare string
+// expression, matching Python's docstring convention.
+func docstring(fn *sitter.Node, src []byte) string {
+	if fn == nil {
+		return ""
+	}
+	body := fn.ChildByFieldName("body")
+	if body == nil || body.NamedChildCount() == 0 {
+		return ""
+	}
+	first := body.NamedChild(0)
+	if first.Type() != "expression_statement" || first.NamedChildCount() == 0 {
+		return ""
+	}
+	if expr := first.NamedChild(0); expr.Type() == "string" {
+		return nodeText(expr, src)
+	}
+	return ""
+}
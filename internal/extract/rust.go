@@ -0,0 +1,69 @@
+package extract
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/rust"
+)
+
+func init() {
+	Register(".rs", &RustExtractor{})
+}
+
+// RustExtractor extracts imports, globals, and funcs from Rust source using
+// tree-sitter.
+type RustExtractor struct{}
+
+func (RustExtractor) Language() string { return "Rust" }
+
+func (RustExtractor) ExtractImports(content string) ([]string, error) {
+	root, src, err := tsParse(rust.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	err = tsEachMatch(rust.GetLanguage(), `(use_declaration) @use`, root, src, func(c map[string]*sitter.Node) {
+		imports = append(imports, nodeText(c["use"], src))
+	})
+	return imports, err
+}
+
+func (RustExtractor) ExtractGlobals(content string) ([]string, error) {
+	root, src, err := tsParse(rust.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var globals []string
+	query := `[(static_item) (const_item)] @global`
+	err = tsEachMatch(rust.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		globals = append(globals, nodeText(c["global"], src))
+	})
+	return globals, err
+}
+
+func (RustExtractor) ExtractFuncs(content string) ([]FuncDecl, error) {
+	root, src, err := tsParse(rust.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []FuncDecl
+	query := `(function_item name: (identifier) @name parameters: (parameters) @params) @func`
+	err = tsEachMatch(rust.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		funcs = append(funcs, FuncDecl{
+			Name:   nodeText(c["name"], src),
+			Recv:   enclosingReceiver(c["func"], implNodeTypes, "type", src),
+			Params: nodeText(c["params"], src),
+			Doc:    leadingComment(c["func"], src),
+		})
+	})
+	return funcs, err
+}
+
+// implNodeTypes are the tree-sitter node types for a Rust impl block, the
+// closest analogue of a Go method receiver: two impl blocks defining the
+// same fn name (commonly "new") are otherwise indistinguishable.
+var implNodeTypes = map[string]bool{
+	"impl_item": true,
+}
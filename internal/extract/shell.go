@@ -0,0 +1,65 @@
+package extract
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+)
+
+func init() {
+	Register(".sh", &ShellExtractor{})
+}
+
+// ShellExtractor extracts sourced files, globals, and funcs from POSIX/bash
+// shell scripts using tree-sitter.
+type ShellExtractor struct{}
+
+func (ShellExtractor) Language() string { return "Shell" }
+
+// ExtractImports treats "source"/"." commands as the shell equivalent of an
+// import, since shell has no native import statement.
+func (ShellExtractor) ExtractImports(content string) ([]string, error) {
+	root, src, err := tsParse(bash.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var imports []string
+	query := `(command name: (command_name) @cmd) @command`
+	err = tsEachMatch(bash.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		name := nodeText(c["cmd"], src)
+		if name == "source" || name == "." {
+			imports = append(imports, nodeText(c["command"], src))
+		}
+	})
+	return imports, err
+}
+
+func (ShellExtractor) ExtractGlobals(content string) ([]string, error) {
+	root, src, err := tsParse(bash.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var globals []string
+	err = tsEachMatch(bash.GetLanguage(), `(program (variable_assignment) @assign)`, root, src, func(c map[string]*sitter.Node) {
+		globals = append(globals, nodeText(c["assign"], src))
+	})
+	return globals, err
+}
+
+func (ShellExtractor) ExtractFuncs(content string) ([]FuncDecl, error) {
+	root, src, err := tsParse(bash.GetLanguage(), content)
+	if err != nil {
+		return nil, err
+	}
+
+	var funcs []FuncDecl
+	query := `(function_definition name: (word) @name) @func`
+	err = tsEachMatch(bash.GetLanguage(), query, root, src, func(c map[string]*sitter.Node) {
+		funcs = append(funcs, FuncDecl{
+			Name: nodeText(c["name"], src),
+			Doc:  leadingComment(c["func"], src),
+		})
+	})
+	return funcs, err
+}
@@ -0,0 +1,100 @@
+package extract
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// tsParse parses content with the given tree-sitter language and returns the
+// root node along with the raw bytes the node positions refer to.
+func tsParse(lang *sitter.Language, content string) (*sitter.Node, []byte, error) {
+	parser := sitter.NewParser()
+	parser.SetLanguage(lang)
+
+	src := []byte(content)
+	tree, err := parser.ParseCtx(context.Background(), nil, src)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing source: %w", err)
+	}
+	return tree.RootNode(), src, nil
+}
+
+// tsEachMatch runs pattern against root and invokes fn once per match with
+// its captures indexed by capture name.
+func tsEachMatch(lang *sitter.Language, pattern string, root *sitter.Node, src []byte, fn func(captures map[string]*sitter.Node)) error {
+	q, err := sitter.NewQuery([]byte(pattern), lang)
+	if err != nil {
+		return fmt.Errorf("compiling query: %w", err)
+	}
+	defer q.Close()
+
+	qc := sitter.NewQueryCursor()
+	defer qc.Close()
+	qc.Exec(q, root)
+
+	for {
+		m, ok := qc.NextMatch()
+		if !ok {
+			break
+		}
+		captures := make(map[string]*sitter.Node, len(m.Captures))
+		for _, c := range m.Captures {
+			captures[q.CaptureNameForId(c.Index)] = c.Node
+		}
+		fn(captures)
+	}
+	return nil
+}
+
+// nodeText returns n's source text, or "" if n is nil.
+func nodeText(n *sitter.Node, src []byte) string {
+	if n == nil {
+		return ""
+	}
+	return n.Content(src)
+}
+
+// commentNodeTypes are the tree-sitter node types used for comments across
+// the grammars this package supports.
+var commentNodeTypes = map[string]bool{
+	"line_comment": true,
+	"comment":      true,
+}
+
+// leadingComment returns the text of the contiguous run of comment nodes
+// immediately preceding n, concatenated in source order. Declarations with
+// no preceding comment return "".
+func leadingComment(n *sitter.Node, src []byte) string {
+	if n == nil {
+		return ""
+	}
+
+	var lines []string
+	for prev := n.PrevSibling(); prev != nil && commentNodeTypes[prev.Type()]; prev = prev.PrevSibling() {
+		lines = append(lines, nodeText(prev, src))
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return strings.Join(lines, "")
+}
+
+// enclosingReceiver walks n's ancestors looking for the nearest one whose
+// type is in containerTypes (e.g. a Rust impl_item or a Python/C# class
+// node), returning the text of that node's fieldName field (its type or
+// class name). Returns "" if n has no such ancestor, matching the plain
+// function / free function case.
+func enclosingReceiver(n *sitter.Node, containerTypes map[string]bool, fieldName string, src []byte) string {
+	if n == nil {
+		return ""
+	}
+	for p := n.Parent(); p != nil; p = p.Parent() {
+		if containerTypes[p.Type()] {
+			return nodeText(p.ChildByFieldName(fieldName), src)
+		}
+	}
+	return ""
+}
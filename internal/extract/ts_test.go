@@ -0,0 +1,61 @@
+package extract
+
+import "testing"
+
+func TestRustExtractorKeepsFullMultiLineDocComment(t *testing.T) {
+	content := "/// Adds two numbers together.\n/// Returns the sum.\nfn add(a: i32, b: i32) -> i32 { a + b }\n"
+	e := RustExtractor{}
+	funcs, err := e.ExtractFuncs(content)
+	if err != nil {
+		t.Fatalf("ExtractFuncs: %v", err)
+	}
+	if len(funcs) != 1 {
+		t.Fatalf("got %d funcs, want 1", len(funcs))
+	}
+	want := "/// Adds two numbers together.\n/// Returns the sum.\n"
+	if funcs[0].Doc != want {
+		t.Errorf("got Doc %q, want %q", funcs[0].Doc, want)
+	}
+}
+
+func TestRustExtractorSetsRecvFromImplBlock(t *testing.T) {
+	content := "impl Foo {\n    fn new() -> Foo { Foo }\n}\nimpl Bar {\n    fn new() -> Bar { Bar }\n}\n"
+	funcs, err := (RustExtractor{}).ExtractFuncs(content)
+	if err != nil {
+		t.Fatalf("ExtractFuncs: %v", err)
+	}
+	if len(funcs) != 2 {
+		t.Fatalf("got %d funcs, want 2", len(funcs))
+	}
+	if funcs[0].Recv != "Foo" || funcs[1].Recv != "Bar" {
+		t.Errorf("got Recvs %q, %q, want Foo, Bar", funcs[0].Recv, funcs[1].Recv)
+	}
+}
+
+func TestPythonExtractorSetsRecvFromClass(t *testing.T) {
+	content := "class Foo:\n    def __init__(self):\n        pass\n\nclass Bar:\n    def __init__(self):\n        pass\n"
+	funcs, err := (PythonExtractor{}).ExtractFuncs(content)
+	if err != nil {
+		t.Fatalf("ExtractFuncs: %v", err)
+	}
+	if len(funcs) != 2 {
+		t.Fatalf("got %d funcs, want 2", len(funcs))
+	}
+	if funcs[0].Recv != "Foo" || funcs[1].Recv != "Bar" {
+		t.Errorf("got Recvs %q, %q, want Foo, Bar", funcs[0].Recv, funcs[1].Recv)
+	}
+}
+
+func TestCSharpExtractorSetsRecvFromClass(t *testing.T) {
+	content := "class Foo {\n    public int Close() { return 1; }\n}\nclass Bar {\n    public int Close() { return 2; }\n}\n"
+	funcs, err := (CSharpExtractor{}).ExtractFuncs(content)
+	if err != nil {
+		t.Fatalf("ExtractFuncs: %v", err)
+	}
+	if len(funcs) != 2 {
+		t.Fatalf("got %d funcs, want 2", len(funcs))
+	}
+	if funcs[0].Recv != "Foo" || funcs[1].Recv != "Bar" {
+		t.Errorf("got Recvs %q, %q, want Foo, Bar", funcs[0].Recv, funcs[1].Recv)
+	}
+}
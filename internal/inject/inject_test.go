@@ -0,0 +1,124 @@
+package inject
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Forgence/Flandra/internal/extract"
+)
+
+func TestFileDryRunProducesDiffWithoutWriting(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "sample.go")
+	content := "package sample\n\nfunc Foo() {}\n"
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := extract.FileCode{Funcs: []extract.FuncDecl{
+		{Name: "Foo", GeneratedDoc: "Foo does a thing."},
+	}}
+
+	res, err := File(filename, content, fc, true)
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	if !res.Changed {
+		t.Fatal("expected Changed to be true")
+	}
+	if !strings.Contains(res.Diff, "Foo does a thing.") {
+		t.Fatalf("diff missing generated doc:\n%s", res.Diff)
+	}
+
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != content {
+		t.Fatalf("dry run modified the file on disk:\n%s", got)
+	}
+}
+
+func TestFileWritesAndBacksUp(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "sample.go")
+	content := "package sample\n\nfunc Foo() {}\n"
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := extract.FileCode{Funcs: []extract.FuncDecl{
+		{Name: "Foo", GeneratedDoc: "Foo does a thing."},
+	}}
+
+	res, err := File(filename, content, fc, false)
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	if !res.Changed {
+		t.Fatal("expected Changed to be true")
+	}
+
+	got, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "// Foo does a thing.\nfunc Foo()") {
+		t.Fatalf("generated doc not injected:\n%s", got)
+	}
+
+	bak, err := os.ReadFile(filename + ".bak")
+	if err != nil {
+		t.Fatalf("expected a .bak backup: %v", err)
+	}
+	if string(bak) != content {
+		t.Fatalf("backup doesn't match original content:\n%s", bak)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected only sample.go and sample.go.bak to remain, got %v", entries)
+	}
+}
+
+func TestFileKeysInjectedDocsByReceiver(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "sample.go")
+	content := `package sample
+
+type Foo struct{}
+type Bar struct{}
+
+func (f *Foo) Close() error { return nil }
+
+func (b *Bar) Close() error { return nil }
+`
+	fc := extract.FileCode{Funcs: []extract.FuncDecl{
+		{Name: "Close", Recv: "Foo", GeneratedDoc: "Close closes the Foo."},
+		{Name: "Close", Recv: "Bar", GeneratedDoc: "Close closes the Bar."},
+	}}
+
+	res, err := File(filename, content, fc, true)
+	if err != nil {
+		t.Fatalf("File: %v", err)
+	}
+	if !strings.Contains(res.Diff, "Close closes the Foo.") || !strings.Contains(res.Diff, "Close closes the Bar.") {
+		t.Fatalf("expected both receivers' docs in the diff:\n%s", res.Diff)
+	}
+}
+
+func TestFileRefusesUnparseableSource(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "broken.go")
+	content := "package sample\n\nfunc Foo( {\n"
+
+	_, err := File(filename, content, extract.FileCode{}, true)
+	if err == nil {
+		t.Fatal("expected an error for unparseable source")
+	}
+}
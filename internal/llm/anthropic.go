@@ -0,0 +1,120 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	anthropicEndpoint = "https://api.anthropic.com/v1/messages"
+	anthropicVersion  = "2023-06-01"
+)
+
+// AnthropicProvider talks to the Anthropic Claude Messages API directly,
+// since Claude doesn't speak the OpenAI chat completion protocol.
+type AnthropicProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewAnthropic returns a Provider backed by the Anthropic Claude API.
+func NewAnthropic(apiKey string) *AnthropicProvider {
+	return &AnthropicProvider{apiKey: apiKey, httpClient: http.DefaultClient}
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	System      string             `json:"system,omitempty"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float32            `json:"temperature"`
+	Messages    []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Complete implements Provider.
+func (p *AnthropicProvider) Complete(ctx context.Context, prompt Prompt) (string, Usage, error) {
+	body, err := json.Marshal(anthropicRequest{
+		Model:       prompt.Model,
+		System:      prompt.System,
+		MaxTokens:   prompt.MaxTokens,
+		Temperature: prompt.Temperature,
+		Messages:    []anthropicMessage{{Role: "user", Content: prompt.User}},
+	})
+	if err != nil {
+		return "", Usage{}, fmt.Errorf("encoding anthropic request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, anthropicEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", Usage{}, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", Usage{}, err
+	}
+
+	var parsed anthropicResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return "", Usage{}, fmt.Errorf("decoding anthropic response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := fmt.Errorf("anthropic API error (status %d): %s", resp.StatusCode, anthropicErrMessage(parsed))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return "", Usage{}, &RetryableError{Err: apiErr}
+		}
+		return "", Usage{}, apiErr
+	}
+
+	var text string
+	for _, block := range parsed.Content {
+		if block.Type == "text" {
+			text += block.Text
+		}
+	}
+
+	usage := Usage{
+		PromptTokens: parsed.Usage.InputTokens,
+		TotalTokens:  parsed.Usage.InputTokens + parsed.Usage.OutputTokens,
+	}
+	return text, usage, nil
+}
+
+func anthropicErrMessage(parsed anthropicResponse) string {
+	if parsed.Error != nil {
+		return parsed.Error.Message
+	}
+	return "unknown error"
+}
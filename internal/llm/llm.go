@@ -0,0 +1,70 @@
+// Package llm abstracts the LLM backend commentgen talks to, so Flandra can
+// target OpenAI, Azure OpenAI, Anthropic Claude, or any OpenAI-compatible
+// local endpoint (Ollama, llama.cpp, LM Studio) without baking a single
+// vendor into the binary.
+package llm
+
+import (
+	"context"
+	"fmt"
+)
+
+// Prompt is a single chat-style completion request, independent of provider.
+type Prompt struct {
+	System      string
+	User        string
+	Model       string
+	Temperature float32
+	MaxTokens   int
+	// JSONMode asks the provider to constrain its response to a JSON object,
+	// for providers that support it. Providers that don't are free to ignore
+	// it; callers should still instruct the model via System/User in case.
+	JSONMode bool
+}
+
+// Usage reports token accounting for a single Complete call.
+type Usage struct {
+	PromptTokens int
+	TotalTokens  int
+}
+
+// Provider completes a single Prompt against an LLM backend.
+type Provider interface {
+	Complete(ctx context.Context, prompt Prompt) (string, Usage, error)
+}
+
+// Config selects and configures a Provider, typically built from -provider,
+// -apiKey, -baseURL, and -apiVersion flags.
+type Config struct {
+	// Provider is one of "openai" (default), "azure", "anthropic", or
+	// "local".
+	Provider string
+	APIKey   string
+	// BaseURL is required for "azure" and "local"; ignored otherwise.
+	BaseURL string
+	// APIVersion overrides the Azure API version; ignored by other
+	// providers.
+	APIVersion string
+}
+
+// New constructs the Provider selected by cfg.Provider.
+func New(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", "openai":
+		return NewOpenAI(cfg.APIKey), nil
+	case "azure":
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("-baseURL is required for -provider=azure")
+		}
+		return NewAzure(cfg.APIKey, cfg.BaseURL, cfg.APIVersion), nil
+	case "anthropic":
+		return NewAnthropic(cfg.APIKey), nil
+	case "local":
+		if cfg.BaseURL == "" {
+			return nil, fmt.Errorf("-baseURL is required for -provider=local")
+		}
+		return NewLocal(cfg.BaseURL, cfg.APIKey), nil
+	default:
+		return nil, fmt.Errorf("unknown -provider %q: must be one of openai, azure, anthropic, local", cfg.Provider)
+	}
+}
@@ -0,0 +1,77 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// OpenAIProvider talks to any backend that speaks the OpenAI chat completion
+// API: OpenAI itself, an Azure OpenAI deployment, or an OpenAI-compatible
+// local server such as Ollama, llama.cpp, or LM Studio. The only difference
+// between those is how the underlying client is configured; see NewOpenAI,
+// NewAzure, and NewLocal.
+type OpenAIProvider struct {
+	client *openai.Client
+}
+
+// NewOpenAI returns a Provider backed by the public OpenAI API.
+func NewOpenAI(apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{client: openai.NewClient(apiKey)}
+}
+
+// NewAzure returns a Provider backed by an Azure OpenAI deployment at
+// baseURL. apiVersion overrides go-openai's default Azure API version if
+// non-empty.
+func NewAzure(apiKey, baseURL, apiVersion string) *OpenAIProvider {
+	cfg := openai.DefaultAzureConfig(apiKey, baseURL)
+	if apiVersion != "" {
+		cfg.APIVersion = apiVersion
+	}
+	return &OpenAIProvider{client: openai.NewClientWithConfig(cfg)}
+}
+
+// NewLocal returns a Provider backed by any OpenAI-compatible local server
+// (Ollama, llama.cpp, LM Studio) listening at baseURL. apiKey is usually
+// ignored by these servers but is sent if set, since some require a
+// placeholder value.
+func NewLocal(baseURL, apiKey string) *OpenAIProvider {
+	cfg := openai.DefaultConfig(apiKey)
+	cfg.BaseURL = baseURL
+	return &OpenAIProvider{client: openai.NewClientWithConfig(cfg)}
+}
+
+// Complete implements Provider.
+func (p *OpenAIProvider) Complete(ctx context.Context, prompt Prompt) (string, Usage, error) {
+	req := openai.ChatCompletionRequest{
+		Model: prompt.Model,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: "system", Content: prompt.System},
+			{Role: "user", Content: prompt.User},
+		},
+		MaxTokens:   prompt.MaxTokens,
+		Temperature: prompt.Temperature,
+		N:           1,
+	}
+	if prompt.JSONMode {
+		req.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
+
+	resp, err := p.client.CreateChatCompletion(ctx, req)
+	if err != nil {
+		var apiErr *openai.APIError
+		if errors.As(err, &apiErr) && isRetryableStatus(apiErr.HTTPStatusCode) {
+			return "", Usage{}, &RetryableError{Err: err}
+		}
+		return "", Usage{}, err
+	}
+
+	usage := Usage{PromptTokens: resp.Usage.PromptTokens, TotalTokens: resp.Usage.TotalTokens}
+	return resp.Choices[0].Message.Content, usage, nil
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
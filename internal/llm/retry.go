@@ -0,0 +1,11 @@
+package llm
+
+// RetryableError wraps an error a Provider judges safe to retry with
+// backoff, typically a rate limit or transient server error. Callers should
+// check for it with errors.As rather than assuming any error is retryable.
+type RetryableError struct {
+	Err error
+}
+
+func (e *RetryableError) Error() string { return e.Err.Error() }
+func (e *RetryableError) Unwrap() error { return e.Err }
@@ -0,0 +1,147 @@
+// Package render turns extracted file data into Flandra's output formats:
+// the original plain-text dump, JSON, JSONL, and Markdown.
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/Forgence/Flandra/internal/extract"
+)
+
+// Format is one of Flandra's supported output formats, selected with -format.
+type Format string
+
+const (
+	Text     Format = "text"
+	JSON     Format = "json"
+	JSONL    Format = "jsonl"
+	Markdown Format = "markdown"
+)
+
+// ParseFormat validates s against the supported formats.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case Text, JSON, JSONL, Markdown:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown -format %q: must be one of text, json, jsonl, markdown", s)
+	}
+}
+
+// FuncSummary is the JSON shape of a single function declaration.
+type FuncSummary struct {
+	Name string `json:"name"`
+	// Recv is the bare receiver type name for a method, or empty for a
+	// plain function. See extract.FuncDecl.Recv.
+	Recv    string `json:"recv,omitempty"`
+	Params  string `json:"params"`
+	Results string `json:"results"`
+	Doc     string `json:"doc"`
+}
+
+// FileSummary is the JSON shape of a single extracted file, independent of
+// any rendered source text.
+type FileSummary struct {
+	Filename string        `json:"filename"`
+	Language string        `json:"language"`
+	Imports  []string      `json:"imports"`
+	Globals  []string      `json:"globals"`
+	Funcs    []FuncSummary `json:"funcs"`
+}
+
+// Summarize converts an extract.FileCode into its JSON-facing FileSummary,
+// combining each function's preserved Doc with any GeneratedDoc supplement.
+func Summarize(filename string, fc extract.FileCode) FileSummary {
+	fs := FileSummary{
+		Filename: filename,
+		Language: fc.Language,
+		Imports:  fc.Imports,
+		Globals:  fc.Globals,
+	}
+	for _, fn := range fc.Funcs {
+		fs.Funcs = append(fs.Funcs, FuncSummary{
+			Name:    fn.Name,
+			Recv:    fn.Recv,
+			Params:  fn.Params,
+			Results: fn.Results,
+			Doc:     mergedDoc(fn),
+		})
+	}
+	return fs
+}
+
+func mergedDoc(fn extract.FuncDecl) string {
+	switch {
+	case fn.Doc != "" && fn.GeneratedDoc != "":
+		return strings.TrimSpace(fn.Doc) + "\n" + strings.TrimSpace(fn.GeneratedDoc)
+	case fn.Doc != "":
+		return strings.TrimSpace(fn.Doc)
+	default:
+		return strings.TrimSpace(fn.GeneratedDoc)
+	}
+}
+
+// RenderedFile is a file's already-rendered source text, used by the text
+// and markdown formats.
+type RenderedFile struct {
+	Filename string
+	Code     string
+}
+
+// WriteJSON writes summaries to w as a single JSON array.
+func WriteJSON(w io.Writer, summaries []FileSummary) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(summaries)
+}
+
+// WriteJSONL writes summaries to w as one JSON object per line.
+func WriteJSONL(w io.Writer, summaries []FileSummary) error {
+	enc := json.NewEncoder(w)
+	for _, fs := range summaries {
+		if err := enc.Encode(fs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMarkdown writes files to w as a heading per file followed by its
+// rendered source in a fenced code block, with the language inferred from
+// the file's extension.
+func WriteMarkdown(w io.Writer, files []RenderedFile) error {
+	for _, f := range files {
+		if _, err := fmt.Fprintf(w, "## %s\n\n```%s\n%s\n```\n\n", f.Filename, markdownLang(f.Filename), strings.TrimRight(f.Code, "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteText writes files to w using Flandra's original plain-text wrapper:
+// each file's rendered source, preceded by a line of three single quotes
+// followed by the filename and followed by a line of three single quotes.
+func WriteText(w io.Writer, files []RenderedFile) error {
+	for _, f := range files {
+		if _, err := fmt.Fprintf(w, "'''%s\n%s\n'''\n", f.Filename, f.Code); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var extToMarkdownLang = map[string]string{
+	".go": "go",
+	".rs": "rust",
+	".py": "python",
+	".cs": "csharp",
+	".sh": "bash",
+}
+
+func markdownLang(filename string) string {
+	return extToMarkdownLang[filepath.Ext(filename)]
+}
@@ -0,0 +1,25 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/Forgence/Flandra/internal/extract"
+)
+
+func TestSummarizeKeepsRecvDistinctForSameNamedMethods(t *testing.T) {
+	fc := extract.FileCode{
+		Language: "Go",
+		Funcs: []extract.FuncDecl{
+			{Name: "Close", Recv: "Foo", Results: "(error)"},
+			{Name: "Close", Recv: "Bar", Results: "(error)"},
+		},
+	}
+
+	fs := Summarize("sample.go", fc)
+	if len(fs.Funcs) != 2 {
+		t.Fatalf("got %d funcs, want 2", len(fs.Funcs))
+	}
+	if fs.Funcs[0].Recv != "Foo" || fs.Funcs[1].Recv != "Bar" {
+		t.Errorf("got Recvs %q, %q, want Foo, Bar", fs.Funcs[0].Recv, fs.Funcs[1].Recv)
+	}
+}
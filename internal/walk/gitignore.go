@@ -0,0 +1,57 @@
+package walk
+
+import (
+	"path/filepath"
+
+	ignore "github.com/sabhiram/go-gitignore"
+)
+
+// gitignoreSet lazily compiles and caches the .gitignore found in each
+// directory it's asked about, so a deep tree only pays for parsing once per
+// directory even though matches() checks every ancestor of every file.
+type gitignoreSet struct {
+	byDir map[string]*ignore.GitIgnore
+}
+
+func newGitignoreSet() *gitignoreSet {
+	return &gitignoreSet{byDir: map[string]*ignore.GitIgnore{}}
+}
+
+func (s *gitignoreSet) forDir(dir string) *ignore.GitIgnore {
+	if gi, ok := s.byDir[dir]; ok {
+		return gi
+	}
+
+	gi, err := ignore.CompileIgnoreFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		gi = nil
+	}
+	s.byDir[dir] = gi
+	return gi
+}
+
+// matches reports whether path is ignored by any .gitignore between root and
+// path's directory, inclusive. isDir marks path as a directory, so that
+// directory-only patterns such as "dist/" match it.
+func (s *gitignoreSet) matches(root, path string, isDir bool) bool {
+	for dir := filepath.Dir(path); ; {
+		if gi := s.forDir(dir); gi != nil {
+			rel, err := filepath.Rel(dir, path)
+			if err == nil && isDir {
+				rel += "/"
+			}
+			if err == nil && gi.MatchesPath(rel) {
+				return true
+			}
+		}
+
+		if dir == root {
+			return false
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,141 @@
+// Package walk discovers the files Flandra should process: it applies the
+// existing size/type/mtime filters plus .gitignore rules, include/exclude
+// globs, and a built-in skiplist for common vendor directories.
+package walk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// vendorDirs are directory names Flandra always skips, regardless of
+// -respectGitignore, since no one wants third-party or VCS-internal code in
+// their extraction output.
+var vendorDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	".git":         true,
+	".hg":          true,
+	".svn":         true,
+}
+
+// Options configures a Walk.
+type Options struct {
+	Dir              string
+	SubDirs          bool
+	MinSize          int64
+	FileType         string
+	ModifiedSince    time.Time
+	RespectGitignore bool
+	// Include, if non-empty, restricts results to repo-relative paths
+	// matching at least one of these doublestar glob patterns.
+	Include []string
+	// Exclude drops any repo-relative path matching one of these doublestar
+	// glob patterns, regardless of Include.
+	Exclude []string
+}
+
+// SkipCounts tallies why candidate paths were dropped, so users can debug
+// why a file they expected is missing from the output.
+type SkipCounts struct {
+	VendorDir int
+	Gitignore int
+	Include   int
+	Exclude   int
+	Size      int
+	Type      int
+	ModTime   int
+}
+
+// Result is the outcome of a Walk: the files that passed every filter, plus
+// counts of what was skipped and why.
+type Result struct {
+	Files   []string
+	Skipped SkipCounts
+}
+
+// Walk discovers files under opts.Dir honoring every configured filter.
+func Walk(opts Options) (Result, error) {
+	var res Result
+
+	var gi *gitignoreSet
+	if opts.RespectGitignore {
+		gi = newGitignoreSet()
+	}
+
+	err := filepath.Walk(opts.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != opts.Dir {
+				if !opts.SubDirs {
+					return filepath.SkipDir
+				}
+				if vendorDirs[info.Name()] {
+					res.Skipped.VendorDir++
+					return filepath.SkipDir
+				}
+				if gi != nil && gi.matches(opts.Dir, path, true) {
+					res.Skipped.Gitignore++
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(opts.Dir, path)
+		if err != nil {
+			return fmt.Errorf("computing relative path for %s: %w", path, err)
+		}
+
+		if gi != nil && gi.matches(opts.Dir, path, false) {
+			res.Skipped.Gitignore++
+			return nil
+		}
+
+		if len(opts.Include) > 0 && !matchesAny(opts.Include, rel) {
+			res.Skipped.Include++
+			return nil
+		}
+		if matchesAny(opts.Exclude, rel) {
+			res.Skipped.Exclude++
+			return nil
+		}
+
+		if info.Size() < opts.MinSize {
+			res.Skipped.Size++
+			return nil
+		}
+		if opts.FileType != "" && filepath.Ext(path) != opts.FileType {
+			res.Skipped.Type++
+			return nil
+		}
+		if !opts.ModifiedSince.IsZero() && info.ModTime().Before(opts.ModifiedSince) {
+			res.Skipped.ModTime++
+			return nil
+		}
+
+		res.Files = append(res.Files, path)
+		return nil
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return res, nil
+}
+
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package walk
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalkRespectsGitignoreFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".gitignore"), "ignored.go\n")
+	writeFile(t, filepath.Join(dir, "ignored.go"), "package main")
+	writeFile(t, filepath.Join(dir, "kept.go"), "package main")
+
+	res, err := Walk(Options{Dir: dir, SubDirs: true, RespectGitignore: true, FileType: ".go"})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(res.Files) != 1 || filepath.Base(res.Files[0]) != "kept.go" {
+		t.Fatalf("got %v, want only kept.go", res.Files)
+	}
+	if res.Skipped.Gitignore != 1 {
+		t.Fatalf("got %+v, want 1 gitignore skip", res.Skipped)
+	}
+}
+
+func TestWalkPrunesIgnoredDirectories(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".gitignore"), "dist/\n")
+	writeFile(t, filepath.Join(dir, "dist", "bundle.go"), "package main")
+	writeFile(t, filepath.Join(dir, "dist", "nested", "deep.go"), "package main")
+	writeFile(t, filepath.Join(dir, "kept.go"), "package main")
+
+	res, err := Walk(Options{Dir: dir, SubDirs: true, RespectGitignore: true, FileType: ".go"})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(res.Files) != 1 || filepath.Base(res.Files[0]) != "kept.go" {
+		t.Fatalf("got %v, want only kept.go (dist/ should be pruned, not descended into)", res.Files)
+	}
+	if res.Skipped.Gitignore != 1 {
+		t.Fatalf("got %+v, want 1 gitignore skip for the pruned dist/ directory", res.Skipped)
+	}
+}
+
+func TestWalkSkipsVendorDirs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "vendor", "dep.go"), "package dep")
+	writeFile(t, filepath.Join(dir, "kept.go"), "package main")
+
+	res, err := Walk(Options{Dir: dir, SubDirs: true})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(res.Files) != 1 || filepath.Base(res.Files[0]) != "kept.go" {
+		t.Fatalf("got %v, want only kept.go", res.Files)
+	}
+	if res.Skipped.VendorDir != 1 {
+		t.Fatalf("got %+v, want 1 vendor skip", res.Skipped)
+	}
+}
+
+func TestWalkIncludeExcludeGlobs(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a.go"), "package main")
+	writeFile(t, filepath.Join(dir, "b.txt"), "hello")
+	writeFile(t, filepath.Join(dir, "a_test.go"), "package main")
+
+	res, err := Walk(Options{
+		Dir:     dir,
+		SubDirs: true,
+		Include: []string{"*.go"},
+		Exclude: []string{"*_test.go"},
+	})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(res.Files) != 1 || filepath.Base(res.Files[0]) != "a.go" {
+		t.Fatalf("got %v, want only a.go", res.Files)
+	}
+}
+
+func TestWalkWithoutSubDirsStaysShallow(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "top.go"), "package main")
+	writeFile(t, filepath.Join(dir, "nested", "deep.go"), "package main")
+
+	res, err := Walk(Options{Dir: dir, SubDirs: false})
+	if err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	var names []string
+	for _, f := range res.Files {
+		names = append(names, filepath.Base(f))
+	}
+	sort.Strings(names)
+	if len(names) != 1 || names[0] != "top.go" {
+		t.Fatalf("got %v, want only top.go", names)
+	}
+}